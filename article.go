@@ -0,0 +1,227 @@
+package metascraper
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// articleCandidateTags are the block-level elements ArticleReader scores as
+// candidates for the page's main content.
+var articleCandidateTags = map[string]bool{
+	"p":       true,
+	"div":     true,
+	"article": true,
+	"section": true,
+	"nav":     true,
+	"aside":   true,
+	"footer":  true,
+}
+
+// articleBoilerplateRe matches class/id values that mark an element as
+// boilerplate (comments, navigation chrome, share widgets, ...) whose
+// content should be stripped out before scoring, rather than merely
+// penalized.
+var articleBoilerplateRe = regexp.MustCompile(`(?i)comment|meta|footer|share`)
+
+// minArticleScore is the minimum score a candidate must reach to be trusted
+// as the page's main content; below it, Page.Article falls back to the
+// page's full body text.
+const minArticleScore = 5.0
+
+// articleCandidate accumulates the text, markup, and scoring inputs for one
+// open block-level element while ArticleReader walks the token stream.
+type articleCandidate struct {
+	tag      string
+	tagBonus float64
+	// text and html reconstruct this candidate's full subtree, the way
+	// Readability clones the winning node's markup wholesale; they're what
+	// bestText/bestHTML are drawn from when this candidate wins.
+	text []byte
+	html bytes.Buffer
+	// ownText/ownCommaCount/ownLinkLen cover only the text this candidate
+	// saw while it was itself the innermost open candidate, not text
+	// belonging to nested candidates (p, div, article, ...); score() uses
+	// these, not text, so a wrapper div doesn't inherit the full length of
+	// everything it contains (real content plus unrelated siblings) and
+	// outscore the actual article it's diluted by. Nested candidates still
+	// contribute to an ancestor's score, just as a decayed amount via
+	// inherited, once they finish.
+	ownText       []byte
+	ownCommaCount int
+	ownLinkLen    int
+	inherited     float64 // half-scores propagated up from already-closed children
+	// isolates is true for nav/aside/footer: their text/markup is their own
+	// to score, but it's chrome, not article content, so it must not keep
+	// climbing into their ancestors the way a div's or p's does.
+	isolates bool
+}
+
+func (c *articleCandidate) score() (score, linkDensity float64) {
+	textLen := len(bytes.TrimSpace(c.ownText))
+	if textLen == 0 {
+		return c.tagBonus + c.inherited, 0
+	}
+	linkDensity = float64(c.ownLinkLen) / float64(textLen)
+	score = float64(textLen)/25.0 + float64(c.ownCommaCount) + c.tagBonus + c.inherited
+	return score, linkDensity
+}
+
+func articleTagBonus(tn string, attrs map[string]string) float64 {
+	switch {
+	case tn == "article":
+		return 5
+	case tn == "div" && attrs["itemprop"] == "articleBody":
+		return 3
+	case tn == "nav" || tn == "aside" || tn == "footer":
+		return -3
+	default:
+		return 0
+	}
+}
+
+func isBoilerplate(attrs map[string]string) bool {
+	return articleBoilerplateRe.MatchString(attrs["class"]) || articleBoilerplateRe.MatchString(attrs["id"])
+}
+
+// ArticleReader implements the TokenReader interface; it scores block-level
+// candidates Readability/arc90-style as the document streams by, and at
+// Done() picks the highest-scoring subtree as the page's main article
+// content. It's a SAX-style approximation: Page.ArticleHTML rebuilds the
+// winning subtree's inner markup from the tokens (every element and text
+// node outside boilerplate), not the original source, so whitespace inside
+// tags and attribute order are not preserved.
+type ArticleReader struct {
+	page *Page
+
+	stack        []*articleCandidate // currently open candidates
+	excludeDepth int                 // >0 while inside a boilerplate element
+	excludeMarks []bool              // per open element: did it increment excludeDepth?
+	linkDepth    int                 // >0 while inside an <a>
+
+	bestScore float64
+	bestText  string
+	bestHTML  string
+}
+
+func (r *ArticleReader) HandleStart(tn string, attrs map[string]string, z *html.Tokenizer) {
+	marksExclude := isBoilerplate(attrs)
+	if marksExclude {
+		r.excludeDepth++
+	}
+	r.excludeMarks = append(r.excludeMarks, marksExclude)
+	if r.excludeDepth == 0 {
+		r.broadcast(serializeStartTag(tn, attrs))
+		if tn == "a" {
+			r.linkDepth++
+		}
+		if articleCandidateTags[tn] {
+			r.stack = append(r.stack, &articleCandidate{
+				tag:      tn,
+				tagBonus: articleTagBonus(tn, attrs),
+				isolates: tn == "nav" || tn == "aside" || tn == "footer",
+			})
+		}
+	}
+}
+
+func (r *ArticleReader) HandleEnd(tn string, z *html.Tokenizer) {
+	if r.excludeDepth == 0 {
+		if articleCandidateTags[tn] && len(r.stack) > 0 {
+			cand := r.stack[len(r.stack)-1]
+			r.stack = r.stack[:len(r.stack)-1]
+			r.finish(cand)
+		}
+		if tn == "a" && r.linkDepth > 0 {
+			r.linkDepth--
+		}
+		r.broadcast(fmt.Sprintf("</%s>", tn))
+	}
+	depth := len(r.excludeMarks)
+	if depth > 0 {
+		marked := r.excludeMarks[depth-1]
+		r.excludeMarks = r.excludeMarks[:depth-1]
+		if marked {
+			r.excludeDepth--
+		}
+	}
+}
+
+func (r *ArticleReader) HandleText(text []byte) {
+	if r.excludeDepth > 0 {
+		return
+	}
+	r.broadcast(html.EscapeString(string(text)))
+	commas := strings.Count(string(text), ",")
+	// Walk from the innermost open candidate outward so nav/aside/footer
+	// still gets its own text reconstructed, but stop there: chrome text
+	// must not climb into an enclosing article/div's reconstructed subtree
+	// the way real content does.
+	for i := len(r.stack) - 1; i >= 0; i-- {
+		cand := r.stack[i]
+		cand.text = append(cand.text, text...)
+		if cand.isolates {
+			break
+		}
+	}
+	// Scoring, unlike reconstruction, only ever looks at the innermost open
+	// candidate: see the ownText field comment.
+	if len(r.stack) > 0 {
+		innermost := r.stack[len(r.stack)-1]
+		innermost.ownText = append(innermost.ownText, text...)
+		innermost.ownCommaCount += commas
+		if r.linkDepth > 0 {
+			innermost.ownLinkLen += len(text)
+		}
+	}
+}
+
+func (r *ArticleReader) broadcast(s string) {
+	for i := len(r.stack) - 1; i >= 0; i-- {
+		cand := r.stack[i]
+		cand.html.WriteString(s)
+		if cand.isolates {
+			break
+		}
+	}
+}
+
+// finish scores a closing candidate, considers it for the page's best
+// article, and propagates half its score up to its still-open parent (the
+// new top of the stack), the way Readability's scorer does.
+func (r *ArticleReader) finish(cand *articleCandidate) {
+	score, linkDensity := cand.score()
+	if linkDensity <= 0.5 && score > r.bestScore {
+		r.bestScore = score
+		r.bestText = strings.TrimSpace(string(cand.text))
+		r.bestHTML = cand.html.String()
+	}
+	if len(r.stack) > 0 {
+		r.stack[len(r.stack)-1].inherited += score * 0.5
+	}
+}
+
+func (r *ArticleReader) Done() {
+	if r.bestScore >= minArticleScore {
+		r.page.Article = r.bestText
+		r.page.ArticleHTML = r.bestHTML
+		return
+	}
+	// No candidate was confident enough; fall back to the full body text
+	// PageReader already collected.
+	r.page.Article = r.page.Text
+}
+
+func serializeStartTag(tn string, attrs map[string]string) string {
+	var b strings.Builder
+	b.WriteByte('<')
+	b.WriteString(tn)
+	for k, v := range attrs {
+		fmt.Fprintf(&b, ` %s="%s"`, k, html.EscapeString(v))
+	}
+	b.WriteByte('>')
+	return b.String()
+}
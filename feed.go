@@ -0,0 +1,57 @@
+package metascraper
+
+import (
+	"golang.org/x/net/html"
+)
+
+// FeedLink represents an RSS or Atom feed advertised by a document, via a
+// `<link rel="alternate">` element in its head.
+type FeedLink struct {
+	Title string // The link's title attribute, if any.
+	Type  string // The link's type attribute, e.g. "application/rss+xml".
+	HREF  string // The link's href attribute.
+}
+
+// feedLinkTypes are the `type` attribute values FeedLinkReader recognizes as
+// feeds rather than ordinary alternate links (e.g. alternate languages).
+var feedLinkTypes = map[string]bool{
+	"application/rss+xml":  true,
+	"application/atom+xml": true,
+}
+
+// FeedLinkReader implements the TokenReader interface; it collects the feed
+// links advertised in a document's head.
+type FeedLinkReader struct {
+	items  []*FeedLink
+	inHead bool
+}
+
+func (r *FeedLinkReader) HandleStart(tn string, attrs map[string]string, z *html.Tokenizer) {
+	switch tn {
+	case "head":
+		r.inHead = true
+	case "link":
+		if !r.inHead || attrs["rel"] != "alternate" || !feedLinkTypes[attrs["type"]] {
+			return
+		}
+		r.items = append(r.items, &FeedLink{
+			Title: attrs["title"],
+			Type:  attrs["type"],
+			HREF:  attrs["href"],
+		})
+	}
+}
+
+func (r *FeedLinkReader) HandleEnd(tn string, z *html.Tokenizer) {
+	if tn == "head" {
+		r.inHead = false
+	}
+}
+
+func (r *FeedLinkReader) HandleText(text []byte) {
+	// link elements carry no text content worth collecting.
+}
+
+func (r *FeedLinkReader) Done() {
+	// No cleanup.
+}
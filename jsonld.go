@@ -0,0 +1,119 @@
+package metascraper
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"golang.org/x/net/html"
+)
+
+// JSONLDReader implements the TokenReader interface; it maintains the
+// necessary state for extracting schema.org metadata embedded as
+// `<script type="application/ld+json">` blocks, which have become the most
+// common way sites publish structured data.
+type JSONLDReader struct {
+	items   []map[string]interface{} // Parsed JSON-LD objects, one per top-level entity.
+	inBlock bool
+	buf     []byte
+}
+
+func (r *JSONLDReader) HandleStart(tn string, attrs map[string]string, z *html.Tokenizer) {
+	if tn == "script" && attrs["type"] == "application/ld+json" {
+		r.inBlock = true
+		r.buf = nil
+	}
+}
+
+func (r *JSONLDReader) HandleEnd(tn string, z *html.Tokenizer) {
+	if tn == "script" && r.inBlock {
+		r.inBlock = false
+		r.parse()
+	}
+}
+
+func (r *JSONLDReader) HandleText(text []byte) {
+	if r.inBlock {
+		r.buf = append(r.buf, text...)
+	}
+}
+
+func (r *JSONLDReader) Done() {
+	// No cleanup.
+}
+
+// parse decodes the buffered contents of a single LD+JSON script block.
+// A block may contain a single object, an object with an "@graph" array of
+// entities, or (less commonly) a top-level array of objects; malformed
+// blocks are simply dropped, since a single bad script shouldn't keep the
+// rest of the page's structured data from being collected.
+func (r *JSONLDReader) parse() {
+	data := bytes.TrimSpace(r.buf)
+	if len(data) == 0 {
+		return
+	}
+	var single map[string]interface{}
+	if err := json.Unmarshal(data, &single); err == nil {
+		if graph, ok := single["@graph"].([]interface{}); ok {
+			for _, g := range graph {
+				if m, ok := g.(map[string]interface{}); ok {
+					r.items = append(r.items, m)
+				}
+			}
+			return
+		}
+		r.items = append(r.items, single)
+		return
+	}
+	var multiple []map[string]interface{}
+	if err := json.Unmarshal(data, &multiple); err == nil {
+		r.items = append(r.items, multiple...)
+	}
+}
+
+// itemScopeToMap flattens a microdata ItemScope into the same plain
+// map[string]interface{} shape JSON-LD entities already have, so callers can
+// treat both sources uniformly.
+func itemScopeToMap(s *ItemScope) map[string]interface{} {
+	m := map[string]interface{}{
+		"@type": s.ItemType,
+	}
+	for _, prop := range s.Props {
+		m[prop.ItemProp] = itemPropValue(prop)
+	}
+	for _, child := range s.Children {
+		m[child.ItemProp] = itemScopeToMap(child)
+	}
+	return m
+}
+
+func itemPropValue(p *ItemProp) interface{} {
+	switch {
+	case p.HREF != "":
+		return p.HREF
+	case p.DateTime != "":
+		return p.DateTime
+	default:
+		return p.Content
+	}
+}
+
+// SchemaOrg gets a unified view of this page's schema.org structured data,
+// merging microdata itemscopes and embedded JSON-LD entities into a single
+// slice of plain maps. Either source is simply omitted if its reader wasn't
+// set before reading the page.
+func (p *Page) SchemaOrg() []map[string]interface{} {
+	var schemaItems []*ItemScope
+	if p.SchemaReader != nil {
+		schemaItems = p.SchemaReader.items
+	}
+	var jsonldItems []map[string]interface{}
+	if p.JSONLDReader != nil {
+		jsonldItems = p.JSONLDReader.items
+	}
+	items := make([]map[string]interface{}, 0, len(schemaItems)+len(jsonldItems))
+	for _, s := range schemaItems {
+		items = append(items, itemScopeToMap(s))
+	}
+	items = append(items, jsonldItems...)
+	return items
+}
@@ -1,9 +1,10 @@
 package metascraper
 
 import (
-	"golang.org/x/net/html"
-	"log"
+	"errors"
 	"strings"
+
+	"golang.org/x/net/html"
 )
 
 // Meta represents a `meta` tag in the head of an HTML document.
@@ -22,6 +23,7 @@ type Meta struct {
 // MetaReader implements the TokenReader interface; it maintains the necessary
 // state for extracting structured metadata from a stream of HTML tokens.
 type MetaReader struct {
+	page   *Page // Set by newPage so recoverable inconsistencies can be reported via page.Errors.
 	items  []*Meta
 	inside bool
 	inHead bool
@@ -105,7 +107,19 @@ func (r *MetaReader) makeCurrentExtra() {
 	e := r.pop()
 	cur, exists := r.current()
 	if !exists {
-		log.Fatalln("No prior meta tag to associate the current tag with.")
+		// Shouldn't happen given the check in HandleStart, but malformed or
+		// unexpected markup could still get us here; keep the tag as its own
+		// top-level entry instead of losing it, and report the inconsistency
+		// rather than taking down the host process over one bad page.
+		r.items = append(r.items, e)
+		r.recordError(errors.New("metascraper: no prior meta tag to associate the current tag with"))
+		return
 	}
 	cur.Extra = append(cur.Extra, e)
 }
+
+func (r *MetaReader) recordError(err error) {
+	if r.page != nil {
+		r.page.Errors = append(r.page.Errors, err)
+	}
+}
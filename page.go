@@ -2,9 +2,11 @@ package metascraper
 
 import (
 	"bytes"
-	"golang.org/x/net/html"
+	"io"
 	"regexp"
 	"strings"
+
+	"golang.org/x/net/html"
 )
 
 var lineFeedReplacer = regexp.MustCompile(`[\n\r]+`)
@@ -12,15 +14,36 @@ var whitespaceReplacer = regexp.MustCompile(`\s+`)
 
 // Page represents an HTML document with metadata.
 type Page struct {
-	URL  string // The web page's URL.
-	HTML string // The web page's raw HTML.
-	Text string // The text content in the body of the web page, sans markup.
+	URL string // The web page's URL.
+	// KeepHTML controls whether Read and ScrapeReader populate HTML below. It
+	// defaults to false so that streaming large pages through ScrapeReader
+	// doesn't hold the whole source in memory just to throw it away.
+	KeepHTML bool
+	HTML     string // The web page's raw HTML, if KeepHTML is set.
+	Text     string // The text content in the body of the web page, sans markup.
 	// Series of more than one line feed are replaced by a single newline.
 	// Series of more than one space are replaced by a single space.
-	Title        string        // The title of the web page, as given in the head's title element.
-	MetaReader   *MetaReader   // A TokenReader for extracting metadata from the document's head.
-	SchemaReader *SchemaReader // A TokenReader for extracting schema.org metadata from the document's body.
-	PageReader   *PageReader   // A TokenReader for extracting the page's title and text content.
+	Title string // The title of the web page, as given in the head's title element.
+	// Article is the page's main content, as picked out by ArticleReader's
+	// Readability-style scorer; it falls back to Text if no candidate scored
+	// high enough. ArticleHTML is the same subtree's reconstructed markup;
+	// it's left empty when Article falls back to Text.
+	Article        string
+	ArticleHTML    string
+	MetaReader     *MetaReader     // A TokenReader for extracting metadata from the document's head.
+	SchemaReader   *SchemaReader   // A TokenReader for extracting schema.org metadata from the document's body.
+	JSONLDReader   *JSONLDReader   // A TokenReader for extracting schema.org metadata from JSON-LD script blocks.
+	FeedLinkReader *FeedLinkReader // A TokenReader for extracting advertised RSS/Atom feed links from the document's head.
+	ArticleReader  *ArticleReader  // A TokenReader for picking out the page's main article content.
+	PageReader     *PageReader     // A TokenReader for extracting the page's title and text content.
+	// RuleReader is an opt-in TokenReader for site-specific extraction rules;
+	// it's left nil (and excluded from Readers()) unless the caller sets it,
+	// e.g. via NewRuleReader.
+	RuleReader *RuleReader
+	// Errors collects recoverable parse inconsistencies hit while reading
+	// the page (e.g. metadata that doesn't fit the shape a reader expects).
+	// Their presence doesn't stop Read or ScrapeReader from completing.
+	Errors []error
 }
 
 // Readers gets a ReaderList aggregating all the TokenReaders associated with
@@ -30,13 +53,32 @@ type Page struct {
 // TODO: Write an example that shows how to extend the Page struct with additional
 // token readers.
 func (p *Page) Readers() ReaderList {
-	return ReaderList{
-		Readers: []TokenReader{
-			p.PageReader,
-			p.MetaReader,
-			p.SchemaReader,
-		},
+	readers := []TokenReader{
+		p.PageReader,
+		p.MetaReader,
+		p.SchemaReader,
+	}
+	// JSONLDReader, FeedLinkReader, ArticleReader, and RuleReader are all
+	// newer than the original three above; the first three are only wired
+	// up by newPage, and RuleReader only when the caller sets it. Callers
+	// who build a Page by hand (as the package doc for this method
+	// invites) may well leave one nil, so each is only included when
+	// present.
+	if p.JSONLDReader != nil {
+		readers = append(readers, p.JSONLDReader)
+	}
+	if p.FeedLinkReader != nil {
+		readers = append(readers, p.FeedLinkReader)
 	}
+	if p.ArticleReader != nil {
+		// ArticleReader.Done reads p.Text for its fallback, so it must run
+		// after PageReader; ReaderList.Done calls readers in list order.
+		readers = append(readers, p.ArticleReader)
+	}
+	if p.RuleReader != nil {
+		readers = append(readers, p.RuleReader)
+	}
+	return ReaderList{Readers: readers}
 }
 
 // MetaData gets the metadata found in this page's head.
@@ -49,11 +91,52 @@ func (p *Page) SchemaData() []*ItemScope {
 	return p.SchemaReader.items
 }
 
+// JSONLD gets the schema.org metadata found in this page's LD+JSON script
+// blocks. It returns nil if no JSONLDReader was set before reading the page.
+func (p *Page) JSONLD() []map[string]interface{} {
+	if p.JSONLDReader == nil {
+		return nil
+	}
+	return p.JSONLDReader.items
+}
+
+// Feeds gets the RSS/Atom feeds this page's head advertises. It returns nil
+// if no FeedLinkReader was set before reading the page.
+func (p *Page) Feeds() []*FeedLink {
+	if p.FeedLinkReader == nil {
+		return nil
+	}
+	return p.FeedLinkReader.items
+}
+
+// Fields gets the values extracted by this page's RuleReader, keyed by field
+// name. It returns nil if no RuleReader was set before reading the page.
+func (p *Page) Fields() map[string]interface{} {
+	if p.RuleReader == nil {
+		return nil
+	}
+	return p.RuleReader.fields
+}
+
 // Read populates the Page struct with content and metadata from the given
 // byte array, which the caller is responsible for assuring is well-formed HTML.
 func (p *Page) Read(text []byte) error {
-	data := bytes.NewReader(text)
-	z := html.NewTokenizer(data)
+	return p.readFrom(bytes.NewReader(text))
+}
+
+// readFrom populates the Page struct with content and metadata read directly
+// from r, which the caller is responsible for assuring is well-formed HTML
+// (and already decoded to UTF-8, e.g. via golang.org/x/net/html/charset).
+// Unlike Read, it never materializes the whole document up front, so it's
+// the more memory-conscious choice for large pages; set Page.KeepHTML before
+// calling it if you still want the raw source collected into p.HTML.
+func (p *Page) readFrom(r io.Reader) error {
+	if p.KeepHTML {
+		buf := &bytes.Buffer{}
+		r = io.TeeReader(r, buf)
+		defer func() { p.HTML = buf.String() }()
+	}
+	z := html.NewTokenizer(r)
 	readers := p.Readers()
 	for {
 		tt := z.Next()
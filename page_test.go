@@ -1,9 +1,11 @@
 package metascraper
 
 import (
-	"github.com/kylelemons/godebug/pretty"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
 )
 
 // sampled from https://schema.org/docs/gs.html and http://ogp.me/
@@ -190,14 +192,25 @@ var schema = []*ItemScope{
 	},
 }
 
-func TestPage(t *testing.T) {
+// TestPageMinimalConstruction exercises the pre-series construction pattern
+// (only MetaReader/SchemaReader/PageReader set, as the old Scrape used to
+// build one) to make sure Readers() doesn't assume the newer optional
+// readers are always present.
+func TestPageMinimalConstruction(t *testing.T) {
 	p := &Page{
 		URL:          "https://www.example.com",
-		HTML:         testPage,
 		MetaReader:   &MetaReader{},
 		SchemaReader: &SchemaReader{},
 	}
 	p.PageReader = &PageReader{page: p}
+	p.Read([]byte(`<html><head><title>T</title></head><body>hi</body></html>`))
+	if p.Title != "T" {
+		t.Errorf("expected title T, got %q", p.Title)
+	}
+}
+
+func TestPage(t *testing.T) {
+	p := newPage("https://www.example.com")
 	mockbytes := []byte(testPage)
 	p.Read(mockbytes)
 	if p.Title != "TestPage" {
@@ -219,3 +232,209 @@ func TestPage(t *testing.T) {
 		}
 	}
 }
+
+// sampled from https://schema.org/docs/gs.html#schemaorg_jsonld, including the
+// @graph form used to publish more than one entity from a single block.
+const jsonldPage = `
+    <html>
+        <head>
+            <title>JSONLDPage</title>
+            <script type="application/ld+json">
+            {
+                "@context": "http://schema.org",
+                "@type": "Movie",
+                "name": "The Rock"
+            }
+            </script>
+            <script type="application/ld+json">
+            {
+                "@context": "http://schema.org",
+                "@graph": [
+                    {"@type": "Person", "name": "Alice Jones"},
+                    {"@type": "Person", "name": "Bob Smith"}
+                ]
+            }
+            </script>
+        </head>
+        <body></body>
+    </html>
+`
+
+func TestJSONLD(t *testing.T) {
+	p := newPage("https://www.example.com")
+	p.Read([]byte(jsonldPage))
+	if len(p.JSONLD()) != 3 {
+		t.Fatalf("expected 3 JSON-LD entities, got %d", len(p.JSONLD()))
+	}
+	if p.JSONLD()[0]["name"] != "The Rock" {
+		t.Errorf("expected first JSON-LD entity to be The Rock, got %+v", p.JSONLD()[0])
+	}
+	if p.JSONLD()[1]["name"] != "Alice Jones" || p.JSONLD()[2]["name"] != "Bob Smith" {
+		t.Errorf("expected @graph entities to be flattened in order, got %+v", p.JSONLD()[1:])
+	}
+}
+
+const feedLinkPage = `
+    <html>
+        <head>
+            <title>FeedLinkPage</title>
+            <link rel="alternate" type="application/rss+xml" title="RSS" href="/feed.rss" />
+            <link rel="alternate" type="application/atom+xml" title="Atom" href="/feed.atom" />
+            <link rel="alternate" type="text/html" title="French" href="/fr/" />
+        </head>
+        <body></body>
+    </html>
+`
+
+func TestFeedLinks(t *testing.T) {
+	p := newPage("https://www.example.com")
+	p.Read([]byte(feedLinkPage))
+	feeds := p.Feeds()
+	if len(feeds) != 2 {
+		t.Fatalf("expected 2 feed links, got %d: %+v", len(feeds), feeds)
+	}
+	if feeds[0].HREF != "/feed.rss" || feeds[1].HREF != "/feed.atom" {
+		t.Errorf("unexpected feed links %+v", feeds)
+	}
+}
+
+const rulePage = `
+    <html>
+        <body>
+            <nav><a href="/about">About</a></nav>
+            <article>
+                <h1 class="headline">  Local Team Wins Championship  </h1>
+                <time datetime="2016-03-05">March 5, 2016</time>
+                <p>Full story text.</p>
+            </article>
+        </body>
+    </html>
+`
+
+func TestRuleReader(t *testing.T) {
+	rules := Rules{
+		"headline": &FieldRule{
+			Selectors: []string{"article h1.headline"},
+			Attr:      "text",
+			Post:      []string{"trim"},
+		},
+		"published": &FieldRule{
+			Selectors: []string{"article time"},
+			Attr:      "@datetime",
+		},
+	}
+	p := newPage("https://www.example.com")
+	p.RuleReader = NewRuleReader(rules)
+	p.Read([]byte(rulePage))
+	if p.Fields()["headline"] != "Local Team Wins Championship" {
+		t.Errorf("expected trimmed headline, got %+v", p.Fields()["headline"])
+	}
+	if p.Fields()["published"] != "2016-03-05" {
+		t.Errorf("expected published date from @datetime, got %+v", p.Fields()["published"])
+	}
+}
+
+const articlePage = `
+    <html>
+        <body>
+            <nav><a href="/1">Home</a><a href="/2">About</a><a href="/3">Contact</a></nav>
+            <article>
+                <p>This is the first paragraph of a long article, with plenty of real content, discussing the topic at hand, and providing useful detail throughout.</p>
+                <p>This is the second paragraph, continuing the story, with more detail, more explanation, and further discussion of the same topic at length.</p>
+            </article>
+            <footer class="site-footer"><p>Copyright 2016, all rights reserved, privacy policy, contact us.</p></footer>
+        </body>
+    </html>
+`
+
+func TestArticleReader(t *testing.T) {
+	p := newPage("https://www.example.com")
+	p.Read([]byte(articlePage))
+	if !strings.Contains(p.Article, "first paragraph") || !strings.Contains(p.Article, "second paragraph") {
+		t.Errorf("expected article text to contain both paragraphs, got %q", p.Article)
+	}
+	if strings.Contains(p.Article, "Home") || strings.Contains(p.Article, "Copyright") {
+		t.Errorf("expected nav and boilerplate footer to be excluded, got %q", p.Article)
+	}
+}
+
+// TestArticleReaderExcludesClasslessBoilerplateTags covers a case
+// articleBoilerplateRe can't catch on its own: a footer with no class or id
+// for it to match. articleTagBonus already penalizes nav/aside/footer in the
+// score, but that alone doesn't stop their text from leaking into an open
+// ancestor candidate, so this exercises that isolation directly.
+func TestArticleReaderExcludesClasslessBoilerplateTags(t *testing.T) {
+	const page = `
+    <html>
+        <body>
+            <article>
+                <p>This is the first paragraph of a long article, with plenty of real content, discussing the topic at hand, and providing useful detail throughout.</p>
+                <footer>Unrelated site footer boilerplate, copyright notice, and links nobody asked for.</footer>
+            </article>
+        </body>
+    </html>
+`
+	p := newPage("https://www.example.com")
+	p.Read([]byte(page))
+	if !strings.Contains(p.Article, "first paragraph") {
+		t.Errorf("expected article text to contain the real paragraph, got %q", p.Article)
+	}
+	if strings.Contains(p.Article, "footer boilerplate") {
+		t.Errorf("expected classless footer text to be excluded from the article, got %q", p.Article)
+	}
+}
+
+// TestArticleReaderPicksArticleOverWrappingDiv covers a real-world shape the
+// flat fixtures above don't: a wrapper div around the article that also has
+// an unrelated sibling (a "related posts" teaser). A wrapper div's score
+// must not include the combined length of everything it contains, or it'll
+// always beat the actual <article> it's diluted by.
+func TestArticleReaderPicksArticleOverWrappingDiv(t *testing.T) {
+	const page = `
+    <html>
+        <body>
+            <div id="wrapper">
+                <header><h1>Site Name</h1></header>
+                <article>
+                    <p>This is the first paragraph of a long article, with plenty of real content, discussing the topic at hand, and providing useful detail throughout.</p>
+                    <p>This is the second paragraph, continuing the story, with more detail, more explanation, and further discussion of the same topic at length.</p>
+                </article>
+                <div class="related">
+                    <p>Related: check out these other unrelated teaser links that have nothing to do with the article above.</p>
+                </div>
+            </div>
+        </body>
+    </html>
+`
+	p := newPage("https://www.example.com")
+	p.Read([]byte(page))
+	if !strings.Contains(p.Article, "first paragraph") || !strings.Contains(p.Article, "second paragraph") {
+		t.Errorf("expected article text to contain both paragraphs, got %q", p.Article)
+	}
+	if strings.Contains(p.Article, "Related") || strings.Contains(p.Article, "Site Name") {
+		t.Errorf("expected the wrapper's unrelated siblings to be excluded, got %q", p.Article)
+	}
+}
+
+func TestMetaReaderRecoversFromInconsistentState(t *testing.T) {
+	p := &Page{}
+	r := &MetaReader{page: p}
+	r.items = append(r.items, &Meta{Property: "og:image:width", Content: "300"})
+	r.makeCurrentExtra()
+	if len(p.Errors) != 1 {
+		t.Fatalf("expected the inconsistency to be recorded, got %d errors", len(p.Errors))
+	}
+	if len(r.items) != 1 || r.items[0].Property != "og:image:width" {
+		t.Errorf("expected the orphaned tag to survive as a top-level entry, got %+v", r.items)
+	}
+}
+
+func TestSchemaReaderRecoversFromInconsistentState(t *testing.T) {
+	p := &Page{}
+	r := &SchemaReader{page: p}
+	r.insideProp = true
+	r.HandleText([]byte("orphan text"))
+	if len(p.Errors) != 1 {
+		t.Fatalf("expected the inconsistency to be recorded, got %d errors", len(p.Errors))
+	}
+}
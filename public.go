@@ -1,33 +1,96 @@
 package metascraper
 
 import (
+	"context"
 	"io"
-	"io/ioutil"
 	"net/http"
+
+	"golang.org/x/net/html/charset"
 )
 
-// Scrape creates a new page and populates its fields from the content found at
-// the given URL.
-func Scrape(url string) (*Page, error) {
+// newPage creates a Page with all of its built-in TokenReaders wired up and
+// ready to read from, but without any content populated yet. The opt-in
+// RuleReader is left nil.
+func newPage(url string) *Page {
 	p := &Page{
-		URL:          url,
-		MetaReader:   &MetaReader{},
-		SchemaReader: &SchemaReader{},
+		URL:            url,
+		JSONLDReader:   &JSONLDReader{},
+		FeedLinkReader: &FeedLinkReader{},
 	}
-	// Unlike the other TokenReaders, the PageReader must manipulate its parent.
+	// Unlike the other TokenReaders, these need to manipulate their parent:
+	// MetaReader and SchemaReader report recoverable inconsistencies via
+	// page.Errors, and PageReader/ArticleReader write page.Text/Article.
+	p.MetaReader = &MetaReader{page: p}
+	p.SchemaReader = &SchemaReader{page: p}
 	p.PageReader = &PageReader{page: p}
-	resp, err := http.Get(url)
-	if err != nil {
+	p.ArticleReader = &ArticleReader{page: p}
+	return p
+}
+
+// Scrape creates a new page and populates its fields from the content found at
+// the given URL.
+func Scrape(url string) (*Page, error) {
+	return ScrapeWithClient(context.Background(), http.DefaultClient, url)
+}
+
+// ScrapeWithClient is like Scrape, but lets the caller supply a context (for
+// cancellation/timeouts) and an *http.Client (for custom transports, proxies,
+// or cookie jars) rather than always going through http.DefaultClient.
+func ScrapeWithClient(ctx context.Context, client *http.Client, url string) (*Page, error) {
+	p := newPage(url)
+	// Scrape keeps the raw HTML around for backwards compatibility; callers
+	// who don't need it can get the memory savings by calling ScrapeReader
+	// directly with KeepHTML left false.
+	p.KeepHTML = true
+	if err := fetchInto(ctx, client, p); err != nil {
 		return p, err
 	}
-	defer resp.Body.Close()
-	htmlBytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
+	return p, nil
+}
+
+// ScrapeReader is like Scrape, but reads the document from r instead of
+// fetching it over HTTP, streaming tokens directly out of r rather than
+// buffering the whole document in memory first. The caller is responsible
+// for any charset decoding r needs before the bytes reach here.
+func ScrapeReader(r io.Reader, url string) (*Page, error) {
+	p := newPage(url)
+	if err := p.readFrom(r); err != nil && err != io.EOF {
 		return p, err
 	}
-	p.HTML = string(htmlBytes)
-	if err = p.Read(htmlBytes); err != io.EOF {
+	return p, nil
+}
+
+// ScrapeWithRules is like Scrape, but additionally runs the Rules that
+// apply to url (per rules.For(url)) against the page and populates
+// Page.Fields with the result.
+func ScrapeWithRules(ctx context.Context, client *http.Client, url string, rules RuleSet) (*Page, error) {
+	p := newPage(url)
+	p.KeepHTML = true
+	p.RuleReader = NewRuleReader(rules.For(url))
+	if err := fetchInto(ctx, client, p); err != nil {
 		return p, err
 	}
 	return p, nil
 }
+
+// fetchInto fetches p.URL with client, decodes it to UTF-8 per its
+// Content-Type header, and streams it through p's TokenReaders.
+func fetchInto(ctx context.Context, client *http.Client, p *Page) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", p.URL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := charset.NewReader(resp.Body, resp.Header.Get("Content-Type"))
+	if err != nil {
+		return err
+	}
+	if err = p.readFrom(body); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package metascraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const scrapeReaderPage = `
+    <html>
+        <head><title>ScrapeReaderPage</title></head>
+        <body><p>hello from a reader</p></body>
+    </html>
+`
+
+func TestScrapeReader(t *testing.T) {
+	p, err := ScrapeReader(strings.NewReader(scrapeReaderPage), "https://www.example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Title != "ScrapeReaderPage" {
+		t.Errorf("expected title ScrapeReaderPage, got %q", p.Title)
+	}
+	if p.Text != "hello from a reader" {
+		t.Errorf("unexpected page text %q", p.Text)
+	}
+	if p.HTML != "" {
+		t.Errorf("expected ScrapeReader to leave HTML empty by default, got %q", p.HTML)
+	}
+}
+
+func TestScrapeWithClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(scrapeReaderPage))
+	}))
+	defer server.Close()
+
+	p, err := ScrapeWithClient(context.Background(), server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Title != "ScrapeReaderPage" {
+		t.Errorf("expected title ScrapeReaderPage, got %q", p.Title)
+	}
+	if !strings.Contains(p.HTML, "hello from a reader") {
+		t.Errorf("expected ScrapeWithClient to keep the raw HTML, got %q", p.HTML)
+	}
+}
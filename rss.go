@@ -0,0 +1,163 @@
+package metascraper
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// Feed represents an RSS 2.0 or Atom 1.0 feed, normalized to a single shape
+// regardless of which format it was parsed from.
+type Feed struct {
+	Title       string
+	Link        string
+	Description string
+	Items       []*FeedItem
+}
+
+// FeedItem represents a single entry in a Feed: an RSS <item> or an Atom
+// <entry>.
+type FeedItem struct {
+	GUID        string
+	Title       string
+	Link        string
+	Description string
+	// Content holds the full item body, from RSS's content:encoded or
+	// Atom's <content>, when the feed provides one beyond the summary.
+	Content string
+	// Published holds the item's pubDate (RSS) or updated (Atom) as given
+	// by the feed; formats vary enough between publishers that parsing it
+	// into a time.Time is left to the caller.
+	Published string
+}
+
+// rss2 mirrors just the RSS 2.0 fields metascraper cares about.
+type rss2 struct {
+	XMLName xml.Name `xml:"rss"`
+	Channel struct {
+		Title       string `xml:"title"`
+		Link        string `xml:"link"`
+		Description string `xml:"description"`
+		Items       []struct {
+			GUID        string `xml:"guid"`
+			Title       string `xml:"title"`
+			Link        string `xml:"link"`
+			Description string `xml:"description"`
+			PubDate     string `xml:"pubDate"`
+			Encoded     string `xml:"encoded"` // content:encoded
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+// atomLink mirrors an Atom <link> element, which (unlike RSS) can appear
+// more than once per feed/entry distinguished by its rel attribute.
+type atomLink struct {
+	Rel  string `xml:"rel,attr"`
+	HREF string `xml:"href,attr"`
+}
+
+// atom1 mirrors just the Atom 1.0 fields metascraper cares about.
+type atom1 struct {
+	XMLName  xml.Name   `xml:"feed"`
+	Title    string     `xml:"title"`
+	Subtitle string     `xml:"subtitle"`
+	Links    []atomLink `xml:"link"`
+	Entries  []struct {
+		ID      string     `xml:"id"`
+		Title   string     `xml:"title"`
+		Links   []atomLink `xml:"link"`
+		Summary string     `xml:"summary"`
+		Content string     `xml:"content"`
+		Updated string     `xml:"updated"`
+	} `xml:"entry"`
+}
+
+// atomLinkHREF picks the alternate link (or, failing that, the first link)
+// out of an Atom <link> list.
+func atomLinkHREF(links []atomLink) string {
+	for _, l := range links {
+		if l.Rel == "alternate" || l.Rel == "" {
+			return l.HREF
+		}
+	}
+	if len(links) > 0 {
+		return links[0].HREF
+	}
+	return ""
+}
+
+// parseFeed parses body as either RSS 2.0 or Atom 1.0, trying RSS first.
+func parseFeed(body []byte) (*Feed, error) {
+	var r rss2
+	if err := xml.Unmarshal(body, &r); err == nil {
+		feed := &Feed{
+			Title:       r.Channel.Title,
+			Link:        r.Channel.Link,
+			Description: r.Channel.Description,
+		}
+		for _, item := range r.Channel.Items {
+			feed.Items = append(feed.Items, &FeedItem{
+				GUID:        item.GUID,
+				Title:       item.Title,
+				Link:        item.Link,
+				Description: item.Description,
+				Content:     item.Encoded,
+				Published:   item.PubDate,
+			})
+		}
+		return feed, nil
+	}
+	var a atom1
+	if err := xml.Unmarshal(body, &a); err == nil {
+		feed := &Feed{
+			Title:       a.Title,
+			Link:        atomLinkHREF(a.Links),
+			Description: a.Subtitle,
+		}
+		for _, entry := range a.Entries {
+			feed.Items = append(feed.Items, &FeedItem{
+				GUID:        entry.ID,
+				Title:       entry.Title,
+				Link:        atomLinkHREF(entry.Links),
+				Description: entry.Summary,
+				Content:     entry.Content,
+				Published:   entry.Updated,
+			})
+		}
+		return feed, nil
+	}
+	return nil, errors.New("metascraper: unrecognized feed format")
+}
+
+// ScrapeFeed fetches and parses the RSS or Atom feed at url, then scrapes
+// each item's linked page through the normal Scrape path. Pages for items
+// whose link is missing or fails to scrape are simply omitted, so a single
+// broken item doesn't fail the whole feed.
+func ScrapeFeed(url string) (*Feed, []*Page, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	feed, err := parseFeed(body)
+	if err != nil {
+		return nil, nil, err
+	}
+	pages := make([]*Page, 0, len(feed.Items))
+	for _, item := range feed.Items {
+		if item.Link == "" {
+			continue
+		}
+		p, err := Scrape(item.Link)
+		if err != nil {
+			continue
+		}
+		pages = append(pages, p)
+	}
+	return feed, pages, nil
+}
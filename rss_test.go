@@ -0,0 +1,87 @@
+package metascraper
+
+import "testing"
+
+const rssFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+    <channel>
+        <title>Example Blog</title>
+        <link>https://blog.example.com</link>
+        <description>An example blog feed.</description>
+        <item>
+            <guid>https://blog.example.com/posts/1</guid>
+            <title>First Post</title>
+            <link>https://blog.example.com/posts/1</link>
+            <description>The first post.</description>
+            <pubDate>Mon, 02 Jan 2006 15:04:05 GMT</pubDate>
+        </item>
+        <item>
+            <guid>https://blog.example.com/posts/2</guid>
+            <title>Second Post</title>
+            <link>https://blog.example.com/posts/2</link>
+            <description>The second post.</description>
+            <pubDate>Tue, 03 Jan 2006 15:04:05 GMT</pubDate>
+        </item>
+    </channel>
+</rss>
+`
+
+func TestParseFeedRSS(t *testing.T) {
+	feed, err := parseFeed([]byte(rssFeed))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if feed.Title != "Example Blog" || feed.Link != "https://blog.example.com" {
+		t.Errorf("unexpected feed metadata: %+v", feed)
+	}
+	if len(feed.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(feed.Items))
+	}
+	if feed.Items[0].Title != "First Post" || feed.Items[0].Link != "https://blog.example.com/posts/1" {
+		t.Errorf("unexpected first item: %+v", feed.Items[0])
+	}
+	if feed.Items[1].Published != "Tue, 03 Jan 2006 15:04:05 GMT" {
+		t.Errorf("unexpected second item pubDate: %+v", feed.Items[1])
+	}
+}
+
+const atomFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+    <title>Example Blog</title>
+    <subtitle>An example blog feed.</subtitle>
+    <link rel="self" href="https://blog.example.com/feed.atom" />
+    <link rel="alternate" href="https://blog.example.com" />
+    <entry>
+        <id>https://blog.example.com/posts/1</id>
+        <title>First Post</title>
+        <link rel="alternate" href="https://blog.example.com/posts/1" />
+        <summary>The first post.</summary>
+        <updated>2006-01-02T15:04:05Z</updated>
+    </entry>
+</feed>
+`
+
+func TestParseFeedAtom(t *testing.T) {
+	feed, err := parseFeed([]byte(atomFeed))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if feed.Title != "Example Blog" || feed.Link != "https://blog.example.com" {
+		t.Errorf("unexpected feed metadata: %+v", feed)
+	}
+	if len(feed.Items) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(feed.Items))
+	}
+	if feed.Items[0].Title != "First Post" || feed.Items[0].Link != "https://blog.example.com/posts/1" {
+		t.Errorf("unexpected entry: %+v", feed.Items[0])
+	}
+	if feed.Items[0].Published != "2006-01-02T15:04:05Z" {
+		t.Errorf("unexpected entry updated time: %+v", feed.Items[0])
+	}
+}
+
+func TestParseFeedUnrecognized(t *testing.T) {
+	if _, err := parseFeed([]byte(`<html></html>`)); err == nil {
+		t.Error("expected an error for an unrecognized feed format")
+	}
+}
@@ -0,0 +1,290 @@
+package metascraper
+
+import (
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"gopkg.in/yaml.v3"
+)
+
+// FieldRule describes how to extract a single field's value out of a page.
+// Selectors are alternatives, like a CSS selector group: the first element
+// in document order that any of them matches supplies the value, whichever
+// selector it was. Attr picks what's extracted from the matched element: "" or
+// "text" for its text content, or "@name" for one of its attributes (e.g.
+// "@href", "@content"). Post names post-processors to run over the raw
+// extracted string, in order: "trim", "parse-date:<layout>" (a Go reference
+// time layout; success yields a time.Time instead of a string), and
+// "replace:<pattern>:<replacement>" (a regexp substitution).
+type FieldRule struct {
+	Selectors []string `json:"selectors" yaml:"selectors"`
+	Attr      string   `json:"attr,omitempty" yaml:"attr,omitempty"`
+	Post      []string `json:"post,omitempty" yaml:"post,omitempty"`
+}
+
+// Rules is one site's or template's set of extraction rules, keyed by the
+// field name the matched value should be stored under in Page.Fields.
+type Rules map[string]*FieldRule
+
+// RuleSet dispatches a Rules based on the host of the page being scraped,
+// so a single process can carry per-domain extraction rules (article body,
+// byline, publish date, ...) rather than one fixed schema.
+type RuleSet struct {
+	Hosts   map[string]Rules // keyed by URL host, e.g. "www.example.com"
+	Default Rules            // used when no entry in Hosts matches
+}
+
+// For picks the Rules that apply to rawurl: an exact host match if one
+// exists in Hosts, falling back to Default (which may be nil).
+func (rs RuleSet) For(rawurl string) Rules {
+	if u, err := url.Parse(rawurl); err == nil {
+		if r, ok := rs.Hosts[u.Host]; ok {
+			return r
+		}
+	}
+	return rs.Default
+}
+
+// LoadRuleSetJSON decodes a Rules from JSON of the shape:
+//
+//	{"title": {"selectors": ["h1.headline"], "attr": "text", "post": ["trim"]}}
+func LoadRuleSetJSON(data []byte) (Rules, error) {
+	var rules Rules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// LoadRuleSetYAML decodes a Rules from the YAML equivalent of the JSON shape
+// documented on LoadRuleSetJSON.
+func LoadRuleSetYAML(data []byte) (Rules, error) {
+	var rules Rules
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// compoundSelector is one "tag#id.class[attr=val]" step of a selector; a
+// full selector is a sequence of these joined by the descendant combinator
+// (whitespace) -- this is intentionally a small subset of CSS, not a full
+// implementation, covering the common cases for per-site extraction rules.
+type compoundSelector struct {
+	tag     string
+	id      string
+	classes []string
+	attrs   map[string]string
+}
+
+var selectorTagRe = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*`)
+var selectorPartRe = regexp.MustCompile(`#[\w-]+|\.[\w-]+|\[[\w-]+=[^\]]*\]`)
+
+// compileSelector parses a selector string into the sequence of compound
+// selectors an element's ancestor chain must match, in order.
+func compileSelector(selector string) []compoundSelector {
+	tokens := strings.Fields(selector)
+	compounds := make([]compoundSelector, 0, len(tokens))
+	for _, tok := range tokens {
+		c := compoundSelector{attrs: map[string]string{}}
+		if tag := selectorTagRe.FindString(tok); tag != "" {
+			c.tag = tag
+			tok = tok[len(tag):]
+		}
+		for _, part := range selectorPartRe.FindAllString(tok, -1) {
+			switch part[0] {
+			case '#':
+				c.id = part[1:]
+			case '.':
+				c.classes = append(c.classes, part[1:])
+			case '[':
+				inner := strings.TrimSuffix(strings.TrimPrefix(part, "["), "]")
+				if kv := strings.SplitN(inner, "=", 2); len(kv) == 2 {
+					c.attrs[kv[0]] = strings.Trim(kv[1], `"'`)
+				}
+			}
+		}
+		compounds = append(compounds, c)
+	}
+	return compounds
+}
+
+// elementFrame is the bit of an element the selector matcher needs; it's
+// what RuleReader pushes onto its stack for every open element.
+type elementFrame struct {
+	tag   string
+	attrs map[string]string
+}
+
+func compoundMatchesElement(c compoundSelector, el elementFrame) bool {
+	if c.tag != "" && c.tag != el.tag {
+		return false
+	}
+	if c.id != "" && el.attrs["id"] != c.id {
+		return false
+	}
+	for _, class := range c.classes {
+		if !hasClass(el.attrs["class"], class) {
+			return false
+		}
+	}
+	for k, v := range c.attrs {
+		if el.attrs[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func hasClass(classAttr, class string) bool {
+	for _, c := range strings.Fields(classAttr) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesStack reports whether stack's last element matches the final
+// compound selector, with the remaining compounds matched by some ancestor
+// further up the stack, in order (descendant-combinator semantics).
+func matchesStack(stack []elementFrame, compounds []compoundSelector) bool {
+	if len(compounds) == 0 || len(stack) == 0 {
+		return false
+	}
+	if !compoundMatchesElement(compounds[len(compounds)-1], stack[len(stack)-1]) {
+		return false
+	}
+	ci := len(compounds) - 2
+	for si := len(stack) - 2; si >= 0 && ci >= 0; si-- {
+		if compoundMatchesElement(compounds[ci], stack[si]) {
+			ci--
+		}
+	}
+	return ci < 0
+}
+
+// ruleMatch tracks a text extraction in progress: the field it's feeding and
+// the stack depth of the element it matched on, so Done/HandleEnd know when
+// to stop accumulating.
+type ruleMatch struct {
+	field string
+	depth int
+	buf   []byte
+}
+
+// RuleReader implements the TokenReader interface; it matches elements
+// against a Rules' selectors as the document streams by and collects the
+// results into a Page.Fields-shaped map. Unlike the built-in readers it's
+// opt-in: set Page.RuleReader (via NewRuleReader) before reading to enable
+// it for a given page.
+type RuleReader struct {
+	rules    Rules
+	compiled map[string][][]compoundSelector // field -> selector alternatives, each compiled
+	stack    []elementFrame
+	active   []*ruleMatch
+	fields   map[string]interface{}
+}
+
+// NewRuleReader compiles rules' selectors and returns a ready-to-use
+// RuleReader.
+func NewRuleReader(rules Rules) *RuleReader {
+	r := &RuleReader{
+		rules:    rules,
+		compiled: make(map[string][][]compoundSelector, len(rules)),
+		fields:   make(map[string]interface{}, len(rules)),
+	}
+	for field, rule := range rules {
+		alts := make([][]compoundSelector, 0, len(rule.Selectors))
+		for _, sel := range rule.Selectors {
+			alts = append(alts, compileSelector(sel))
+		}
+		r.compiled[field] = alts
+	}
+	return r
+}
+
+func (r *RuleReader) HandleStart(tn string, attrs map[string]string, z *html.Tokenizer) {
+	r.stack = append(r.stack, elementFrame{tag: tn, attrs: attrs})
+	for field, alts := range r.compiled {
+		if _, done := r.fields[field]; done {
+			continue
+		}
+		for _, compounds := range alts {
+			if !matchesStack(r.stack, compounds) {
+				continue
+			}
+			rule := r.rules[field]
+			if strings.HasPrefix(rule.Attr, "@") {
+				r.fields[field] = applyPost(attrs[strings.TrimPrefix(rule.Attr, "@")], rule.Post)
+			} else {
+				r.active = append(r.active, &ruleMatch{field: field, depth: len(r.stack)})
+			}
+			break
+		}
+	}
+}
+
+func (r *RuleReader) HandleText(text []byte) {
+	for _, m := range r.active {
+		m.buf = append(m.buf, text...)
+	}
+}
+
+func (r *RuleReader) HandleEnd(tn string, z *html.Tokenizer) {
+	depth := len(r.stack)
+	for len(r.active) > 0 && r.active[len(r.active)-1].depth == depth {
+		r.finish(r.active[len(r.active)-1])
+		r.active = r.active[:len(r.active)-1]
+	}
+	if depth > 0 {
+		r.stack = r.stack[:depth-1]
+	}
+}
+
+func (r *RuleReader) Done() {
+	// A truncated document can leave matches open; record what they
+	// collected so far rather than silently dropping them.
+	for i := len(r.active) - 1; i >= 0; i-- {
+		r.finish(r.active[i])
+	}
+	r.active = nil
+}
+
+func (r *RuleReader) finish(m *ruleMatch) {
+	rule := r.rules[m.field]
+	r.fields[m.field] = applyPost(strings.TrimSpace(string(m.buf)), rule.Post)
+}
+
+// applyPost runs val through post, in order, stopping early if a
+// processor's result stops being a string (e.g. parse-date succeeding).
+func applyPost(val string, post []string) interface{} {
+	var result interface{} = val
+	for _, p := range post {
+		s, ok := result.(string)
+		if !ok {
+			break
+		}
+		switch {
+		case p == "trim":
+			result = strings.TrimSpace(s)
+		case strings.HasPrefix(p, "parse-date:"):
+			layout := strings.TrimPrefix(p, "parse-date:")
+			if t, err := time.Parse(layout, strings.TrimSpace(s)); err == nil {
+				result = t
+			}
+		case strings.HasPrefix(p, "replace:"):
+			parts := strings.SplitN(strings.TrimPrefix(p, "replace:"), ":", 2)
+			if len(parts) == 2 {
+				if re, err := regexp.Compile(parts[0]); err == nil {
+					result = re.ReplaceAllString(s, parts[1])
+				}
+			}
+		}
+	}
+	return result
+}
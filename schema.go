@@ -1,8 +1,9 @@
 package metascraper
 
 import (
+	"errors"
+
 	"golang.org/x/net/html"
-	"log"
 )
 
 // ItemScope represents a schema.org itemscope.
@@ -29,6 +30,7 @@ type ItemProp struct {
 // SchemaReader implements the TokenReader interface; it maintains the necessary
 // state for extracting schema.org metadata from the body of an HTML document.
 type SchemaReader struct {
+	page        *Page        // Set by newPage so recoverable inconsistencies can be reported via page.Errors.
 	items       []*ItemScope // Top-level ItemScopes.
 	stack       []*ItemScope // The current hierarchy of ItemScopes.
 	breadcrumbs []bool       // Markers indicating whether the current element is an ItemScope.
@@ -122,7 +124,11 @@ func (r *SchemaReader) HandleText(text []byte) {
 	if r.insideProp {
 		s, exists := r.current()
 		if !exists || len(s.Props) == 0 {
-			log.Fatalln("No prop to set content from text node")
+			// Shouldn't happen given how insideProp is set in HandleStart,
+			// but malformed markup could still get us here; skip this text
+			// node and report the inconsistency rather than crashing.
+			r.recordError(errors.New("metascraper: text node inside an itemprop but no prop to set its content on"))
+			return
 		}
 		s.Props[len(s.Props)-1].Content = string(text)
 	}
@@ -131,3 +137,9 @@ func (r *SchemaReader) HandleText(text []byte) {
 func (r *SchemaReader) Done() {
 	// No cleanup.
 }
+
+func (r *SchemaReader) recordError(err error) {
+	if r.page != nil {
+		r.page.Errors = append(r.page.Errors, err)
+	}
+}